@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsWafRegionalRuleGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsWafRegionalRuleGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"metric_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"activated_rule": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"override_action": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"rule_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAwsWafRegionalRuleGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafregionalconn
+	name := d.Get("name").(string)
+
+	ruleGroupId, err := findWafRuleGroupId(conn, name)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(ruleGroupId)
+
+	activatedRules, err := listWafActivatedRulesInRuleGroup(conn, ruleGroupId)
+	if err != nil {
+		return fmt.Errorf("Error reading WAF Regional Rule Group (%s) activated rules: %s", ruleGroupId, err)
+	}
+
+	d.Set("activated_rule", flattenWafActivatedRules(activatedRules))
+
+	getResp, err := conn.GetRuleGroup(&waf.GetRuleGroupInput{
+		RuleGroupId: aws.String(ruleGroupId),
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading WAF Regional Rule Group (%s): %s", ruleGroupId, err)
+	}
+
+	d.Set("metric_name", getResp.RuleGroup.MetricName)
+
+	return nil
+}
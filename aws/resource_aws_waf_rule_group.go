@@ -5,8 +5,10 @@ import (
 	"log"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/waf"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func resourceAwsWafRuleGroup() *schema.Resource {
@@ -15,8 +17,17 @@ func resourceAwsWafRuleGroup() *schema.Resource {
 		Read:   resourceAwsWafRuleGroupRead,
 		Update: resourceAwsWafRuleGroupUpdate,
 		Delete: resourceAwsWafRuleGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceAwsWafRuleGroupCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -42,6 +53,11 @@ func resourceAwsWafRuleGroup() *schema.Resource {
 									"type": {
 										Type:     schema.TypeString,
 										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											waf.WafActionTypeBlock,
+											waf.WafActionTypeAllow,
+											waf.WafActionTypeCount,
+										}, false),
 									},
 								},
 							},
@@ -55,6 +71,10 @@ func resourceAwsWafRuleGroup() *schema.Resource {
 									"type": {
 										Type:     schema.TypeString,
 										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											waf.WafOverrideActionTypeNone,
+											waf.WafOverrideActionTypeCount,
+										}, false),
 									},
 								},
 							},
@@ -75,10 +95,45 @@ func resourceAwsWafRuleGroup() *schema.Resource {
 					},
 				},
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
 
+// resourceAwsWafRuleGroupCustomizeDiff enforces WAF's semantic rules for
+// activated_rule: GROUP rules are only ever evaluated through their
+// override_action and must not set action, while regular and rate-based
+// rules require action and must not set override_action. Catching this at
+// plan time avoids burning a change token on an UpdateRuleGroup call that
+// AWS would reject anyway.
+func resourceAwsWafRuleGroupCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	for _, r := range diff.Get("activated_rule").(*schema.Set).List() {
+		rule := r.(map[string]interface{})
+
+		hasAction := len(rule["action"].([]interface{})) > 0
+		hasOverrideAction := len(rule["override_action"].([]interface{})) > 0
+		ruleType := rule["type"].(string)
+
+		if ruleType == waf.WafRuleTypeGroup {
+			if !hasOverrideAction {
+				return fmt.Errorf("override_action is required when activated_rule type is %s", waf.WafRuleTypeGroup)
+			}
+			if hasAction {
+				return fmt.Errorf("action must not be set when activated_rule type is %s", waf.WafRuleTypeGroup)
+			}
+		} else {
+			if !hasAction {
+				return fmt.Errorf("action is required when activated_rule type is %s", ruleType)
+			}
+			if hasOverrideAction {
+				return fmt.Errorf("override_action must not be set when activated_rule type is %s", ruleType)
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceAwsWafRuleGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).wafconn
 
@@ -97,9 +152,28 @@ func resourceAwsWafRuleGroupCreate(d *schema.ResourceData, meta interface{}) err
 	}
 	resp := out.(*waf.CreateRuleGroupOutput)
 	d.SetId(*resp.RuleGroup.RuleGroupId)
+
+	if v, ok := d.GetOk("tags"); ok {
+		if err := wafCreateTags(conn, wafRuleGroupArn(meta, d.Id()), v.(map[string]interface{})); err != nil {
+			return fmt.Errorf("Error tagging WAF Rule Group (%s): %s", d.Id(), err)
+		}
+	}
+
 	return resourceAwsWafRuleGroupUpdate(d, meta)
 }
 
+// wafRuleGroupArn builds the ARN of a WAF Classic (global) rule group from
+// its id, since GetRuleGroup does not return one.
+func wafRuleGroupArn(meta interface{}, id string) string {
+	client := meta.(*AWSClient)
+	return arn.ARN{
+		Partition: client.partition,
+		Service:   "waf",
+		AccountID: client.accountid,
+		Resource:  fmt.Sprintf("rulegroup/%s", id),
+	}.String()
+}
+
 func resourceAwsWafRuleGroupRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).wafconn
 
@@ -118,14 +192,29 @@ func resourceAwsWafRuleGroupRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	rResp, err := conn.ListActivatedRulesInRuleGroup(&waf.ListActivatedRulesInRuleGroupInput{
-		RuleGroupId: aws.String(d.Id()),
-	})
+	activatedRules, err := listWafActivatedRulesInRuleGroup(conn, d.Id())
+	if err != nil {
+		if isAWSErr(err, "WAFNonexistentItemException", "") {
+			log.Printf("[WARN] WAF Rule Group (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading WAF Rule Group (%s) activated rules: %s", d.Id(), err)
+	}
 
-	d.Set("activated_rule", flattenWafActivatedRules(rResp.ActivatedRules))
+	arn := wafRuleGroupArn(meta, d.Id())
+	d.Set("arn", arn)
+	d.Set("activated_rule", flattenWafActivatedRules(activatedRules))
 	d.Set("name", resp.RuleGroup.Name)
 	d.Set("metric_name", resp.RuleGroup.MetricName)
 
+	tags, err := wafListTags(conn, arn)
+	if err != nil {
+		return fmt.Errorf("Error reading tags for WAF Rule Group (%s): %s", d.Id(), err)
+	}
+	d.Set("tags", tags)
+
 	return nil
 }
 
@@ -136,12 +225,20 @@ func resourceAwsWafRuleGroupUpdate(d *schema.ResourceData, meta interface{}) err
 		o, n := d.GetChange("activated_rule")
 		oldRules, newRules := o.(*schema.Set).List(), n.(*schema.Set).List()
 
-		err := updateWafRuleGroupResource(d.Id(), oldRules, newRules, conn)
+		wr := newWafRetryer(conn, "global")
+		err := updateWafRuleGroupResource(d.Id(), oldRules, newRules, conn, wr)
 		if err != nil {
 			return fmt.Errorf("Error Updating WAF Rule Group: %s", err)
 		}
 	}
 
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+		if err := wafUpdateTags(conn, wafRuleGroupArn(meta, d.Id()), o.(map[string]interface{}), n.(map[string]interface{})); err != nil {
+			return fmt.Errorf("Error updating WAF Rule Group (%s) tags: %s", d.Id(), err)
+		}
+	}
+
 	return resourceAwsWafRuleGroupRead(d, meta)
 }
 
@@ -158,15 +255,16 @@ func resourceAwsWafRuleGroupDelete(d *schema.ResourceData, meta interface{}) err
 }
 
 func deleteWafRuleGroup(id string, oldRules []interface{}, conn *waf.WAF) error {
+	wr := newWafRetryer(conn, "global")
+
 	if len(oldRules) > 0 {
 		noRules := []interface{}{}
-		err := updateWafRuleGroupResource(id, oldRules, noRules, conn)
+		err := updateWafRuleGroupResource(id, oldRules, noRules, conn, wr)
 		if err != nil {
 			return fmt.Errorf("Error updating WAF Rule Group Predicates: %s", err)
 		}
 	}
 
-	wr := newWafRetryer(conn, "global")
 	_, err := wr.RetryWithToken(func(token *string) (interface{}, error) {
 		req := &waf.DeleteRuleGroupInput{
 			ChangeToken: token,
@@ -181,97 +279,67 @@ func deleteWafRuleGroup(id string, oldRules []interface{}, conn *waf.WAF) error
 	return nil
 }
 
-func updateWafRuleGroupResource(id string, oldRules, newRules []interface{}, conn *waf.WAF) error {
-	wr := newWafRetryer(conn, "global")
-	_, err := wr.RetryWithToken(func(token *string) (interface{}, error) {
-		req := &waf.UpdateRuleGroupInput{
-			ChangeToken: token,
-			RuleGroupId: aws.String(id),
-			Updates:     diffWafRuleGroupActivatedRules(oldRules, newRules),
-		}
-
-		return conn.UpdateRuleGroup(req)
+// wafListTags returns the tags currently attached to a WAF resource ARN.
+func wafListTags(conn *waf.WAF, resourceArn string) (map[string]interface{}, error) {
+	resp, err := conn.ListTagsForResource(&waf.ListTagsForResourceInput{
+		ResourceARN: aws.String(resourceArn),
 	})
 	if err != nil {
-		return fmt.Errorf("Error Updating WAF Rule Group: %s", err)
+		return nil, err
 	}
 
-	return nil
+	tags := make(map[string]interface{})
+	for _, t := range resp.TagInfoForResource.TagList {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return tags, nil
 }
 
-func diffWafRuleGroupActivatedRules(oldRules, newRules []interface{}) []*waf.RuleGroupUpdate {
-	updates := make([]*waf.RuleGroupUpdate, 0)
-
-	for _, op := range oldRules {
-		rule := op.(map[string]interface{})
-
-		if idx, contains := sliceContainsMap(newRules, rule); contains {
-			newRules = append(newRules[:idx], newRules[idx+1:]...)
-			continue
-		}
-
-		updates = append(updates, &waf.RuleGroupUpdate{
-			Action:        aws.String(waf.ChangeActionDelete),
-			ActivatedRule: expandWafActivatedRule(rule),
-		})
+func wafCreateTags(conn *waf.WAF, resourceArn string, tags map[string]interface{}) error {
+	if len(tags) == 0 {
+		return nil
 	}
 
-	for _, np := range newRules {
-		rule := np.(map[string]interface{})
+	_, err := conn.TagResource(&waf.TagResourceInput{
+		ResourceARN: aws.String(resourceArn),
+		Tags:        wafTagsFromMap(tags),
+	})
+	return err
+}
 
-		updates = append(updates, &waf.RuleGroupUpdate{
-			Action:        aws.String(waf.ChangeActionInsert),
-			ActivatedRule: expandWafActivatedRule(rule),
+func wafUpdateTags(conn *waf.WAF, resourceArn string, oldTags, newTags map[string]interface{}) error {
+	if removeKeys := wafTagKeysToRemove(oldTags, newTags); len(removeKeys) > 0 {
+		_, err := conn.UntagResource(&waf.UntagResourceInput{
+			ResourceARN: aws.String(resourceArn),
+			TagKeys:     removeKeys,
 		})
+		if err != nil {
+			return err
+		}
 	}
-	return updates
+
+	return wafCreateTags(conn, resourceArn, newTags)
 }
 
-func flattenWafActivatedRules(activatedRules []*waf.ActivatedRule) []interface{} {
-	out := make([]interface{}, len(activatedRules), len(activatedRules))
-	for i, ar := range activatedRules {
-		rule := map[string]interface{}{
-			"priority": int(*ar.Priority),
-			"rule_id":  *ar.RuleId,
-			"type":     *ar.Type,
+// wafTagKeysToRemove returns the keys present in oldTags but absent from
+// newTags, so they can be explicitly untagged before the new set is applied.
+func wafTagKeysToRemove(oldTags, newTags map[string]interface{}) []*string {
+	var keys []*string
+	for k := range oldTags {
+		if _, ok := newTags[k]; !ok {
+			keys = append(keys, aws.String(k))
 		}
-		if ar.Action != nil {
-			rule["action"] = []interface{}{
-				map[string]interface{}{
-					"type": *ar.Action.Type,
-				},
-			}
-		}
-		if ar.OverrideAction != nil {
-			rule["override_action"] = []interface{}{
-				map[string]interface{}{
-					"type": *ar.OverrideAction.Type,
-				},
-			}
-		}
-		out[i] = rule
 	}
-	return out
+	return keys
 }
 
-func expandWafActivatedRule(rule map[string]interface{}) *waf.ActivatedRule {
-	r := &waf.ActivatedRule{
-		Priority: aws.Int64(int64(rule["priority"].(int))),
-		RuleId:   aws.String(rule["rule_id"].(string)),
-		Type:     aws.String(rule["type"].(string)),
-	}
-
-	if a, ok := rule["action"].([]interface{}); ok && len(a) > 0 {
-		m := a[0].(map[string]interface{})
-		r.Action = &waf.WafAction{
-			Type: aws.String(m["type"].(string)),
-		}
-	}
-	if a, ok := rule["override_action"].([]interface{}); ok && len(a) > 0 {
-		m := a[0].(map[string]interface{})
-		r.OverrideAction = &waf.WafOverrideAction{
-			Type: aws.String(m["type"].(string)),
-		}
+func wafTagsFromMap(m map[string]interface{}) []*waf.Tag {
+	tags := make([]*waf.Tag, 0, len(m))
+	for k, v := range m {
+		tags = append(tags, &waf.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v.(string)),
+		})
 	}
-	return r
+	return tags
 }
@@ -0,0 +1,231 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSWafRuleGroup_basic(t *testing.T) {
+	var group waf.RuleGroup
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "aws_waf_rule_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafRuleGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafRuleGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "metric_name", rName),
+					func(s *terraform.State) error {
+						return testAccCheckResourceAttrGlobalARN(resourceName, "arn", "waf", fmt.Sprintf("rulegroup/%s", aws.StringValue(group.RuleGroupId)))(s)
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestAccAWSWafRuleGroup_import follows the same pattern as aws_waf_rule's
+// import test: create a rule group with tags, then verify that importing it
+// by id reproduces the exact same state, exercising the arn/tags wiring
+// added to resourceAwsWafRuleGroupRead.
+func TestAccAWSWafRuleGroup_import(t *testing.T) {
+	var group waf.RuleGroup
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "aws_waf_rule_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafRuleGroupConfigTags(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafRuleGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", rName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAccAWSWafRuleGroup_disappears deletes the rule group out-of-band
+// after apply and before the follow-up refresh, locking in that
+// resourceAwsWafRuleGroupRead treats a WAFNonexistentItemException as the
+// resource no longer existing rather than surfacing it as an error.
+func TestAccAWSWafRuleGroup_disappears(t *testing.T) {
+	var group waf.RuleGroup
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "aws_waf_rule_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafRuleGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafRuleGroupExists(resourceName, &group),
+					testAccCheckResourceDisappears(testAccProvider, resourceAwsWafRuleGroup(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccAWSWafRuleGroup_validation exercises the four rejection paths in
+// resourceAwsWafRuleGroupCustomizeDiff. CustomizeDiff runs entirely
+// client-side at plan time, so these configs use a placeholder rule_id
+// rather than a real aws_waf_rule.
+func TestAccAWSWafRuleGroup_validation(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafRuleGroupConfigActivatedRule(rName, "GROUP", `
+    action {
+      type = "BLOCK"
+    }
+    override_action {
+      type = "NONE"
+    }`),
+				ExpectError: regexp.MustCompile(`action must not be set when activated_rule type is GROUP`),
+			},
+			{
+				Config:      testAccAWSWafRuleGroupConfigActivatedRule(rName, "GROUP", ""),
+				ExpectError: regexp.MustCompile(`override_action is required when activated_rule type is GROUP`),
+			},
+			{
+				Config:      testAccAWSWafRuleGroupConfigActivatedRule(rName, "REGULAR", ""),
+				ExpectError: regexp.MustCompile(`action is required when activated_rule type is REGULAR`),
+			},
+			{
+				Config: testAccAWSWafRuleGroupConfigActivatedRule(rName, "REGULAR", `
+    action {
+      type = "BLOCK"
+    }
+    override_action {
+      type = "NONE"
+    }`),
+				ExpectError: regexp.MustCompile(`override_action must not be set when activated_rule type is REGULAR`),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSWafRuleGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).wafconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_waf_rule_group" {
+			continue
+		}
+
+		resp, err := conn.GetRuleGroup(&waf.GetRuleGroupInput{
+			RuleGroupId: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			if resp.RuleGroup != nil && aws.StringValue(resp.RuleGroup.RuleGroupId) == rs.Primary.ID {
+				return fmt.Errorf("WAF Rule Group %s still exists", rs.Primary.ID)
+			}
+		}
+
+		if !isAWSErr(err, "WAFNonexistentItemException", "") {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSWafRuleGroupExists(n string, group *waf.RuleGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No WAF Rule Group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).wafconn
+		resp, err := conn.GetRuleGroup(&waf.GetRuleGroupInput{
+			RuleGroupId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if aws.StringValue(resp.RuleGroup.RuleGroupId) != rs.Primary.ID {
+			return fmt.Errorf("WAF Rule Group not found")
+		}
+
+		*group = *resp.RuleGroup
+		return nil
+	}
+}
+
+func testAccAWSWafRuleGroupConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_waf_rule_group" "test" {
+  name        = %[1]q
+  metric_name = %[1]q
+}
+`, name)
+}
+
+func testAccAWSWafRuleGroupConfigActivatedRule(name, ruleType, block string) string {
+	return fmt.Sprintf(`
+resource "aws_waf_rule_group" "test" {
+  name        = %[1]q
+  metric_name = %[1]q
+
+  activated_rule {
+    priority = 1
+    rule_id  = "fake-rule-id"
+    type     = %[2]q
+
+    %[3]s
+  }
+}
+`, name, ruleType, block)
+}
+
+func testAccAWSWafRuleGroupConfigTags(name string) string {
+	return fmt.Sprintf(`
+resource "aws_waf_rule_group" "test" {
+  name        = %[1]q
+  metric_name = %[1]q
+
+  tags = {
+    Name = %[1]q
+  }
+}
+`, name)
+}
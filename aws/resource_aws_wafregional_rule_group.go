@@ -0,0 +1,206 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAwsWafRegionalRuleGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsWafRegionalRuleGroupCreate,
+		Read:   resourceAwsWafRegionalRuleGroupRead,
+		Update: resourceAwsWafRegionalRuleGroupUpdate,
+		Delete: resourceAwsWafRegionalRuleGroupDelete,
+
+		CustomizeDiff: resourceAwsWafRuleGroupCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"metric_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateWafMetricName,
+			},
+			"activated_rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeList,
+							MaxItems: 1,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											waf.WafActionTypeBlock,
+											waf.WafActionTypeAllow,
+											waf.WafActionTypeCount,
+										}, false),
+									},
+								},
+							},
+						},
+						"override_action": {
+							Type:     schema.TypeList,
+							MaxItems: 1,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											waf.WafOverrideActionTypeNone,
+											waf.WafOverrideActionTypeCount,
+										}, false),
+									},
+								},
+							},
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"rule_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  waf.WafRuleTypeRegular,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsWafRegionalRuleGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafregionalconn
+	region := meta.(*AWSClient).region
+
+	wr := newWafRegionalRetryer(conn, region)
+	out, err := wr.RetryWithToken(func(token *string) (interface{}, error) {
+		params := &waf.CreateRuleGroupInput{
+			ChangeToken: token,
+			MetricName:  aws.String(d.Get("metric_name").(string)),
+			Name:        aws.String(d.Get("name").(string)),
+		}
+
+		return conn.CreateRuleGroup(params)
+	})
+	if err != nil {
+		return err
+	}
+	resp := out.(*waf.CreateRuleGroupOutput)
+	d.SetId(*resp.RuleGroup.RuleGroupId)
+	return resourceAwsWafRegionalRuleGroupUpdate(d, meta)
+}
+
+func resourceAwsWafRegionalRuleGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafregionalconn
+
+	params := &waf.GetRuleGroupInput{
+		RuleGroupId: aws.String(d.Id()),
+	}
+
+	resp, err := conn.GetRuleGroup(params)
+	if err != nil {
+		if isAWSErr(err, "WAFNonexistentItemException", "") {
+			log.Printf("[WARN] WAF Regional Rule Group (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	activatedRules, err := listWafActivatedRulesInRuleGroup(conn, d.Id())
+	if err != nil {
+		if isAWSErr(err, "WAFNonexistentItemException", "") {
+			log.Printf("[WARN] WAF Regional Rule Group (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading WAF Regional Rule Group (%s) activated rules: %s", d.Id(), err)
+	}
+
+	d.Set("activated_rule", flattenWafActivatedRules(activatedRules))
+	d.Set("name", resp.RuleGroup.Name)
+	d.Set("metric_name", resp.RuleGroup.MetricName)
+
+	return nil
+}
+
+func resourceAwsWafRegionalRuleGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafregionalconn
+	region := meta.(*AWSClient).region
+
+	if d.HasChange("activated_rule") {
+		o, n := d.GetChange("activated_rule")
+		oldRules, newRules := o.(*schema.Set).List(), n.(*schema.Set).List()
+
+		wr := newWafRegionalRetryer(conn, region)
+		err := updateWafRuleGroupResource(d.Id(), oldRules, newRules, conn, wr)
+		if err != nil {
+			return fmt.Errorf("Error Updating WAF Regional Rule Group: %s", err)
+		}
+	}
+
+	return resourceAwsWafRegionalRuleGroupRead(d, meta)
+}
+
+func resourceAwsWafRegionalRuleGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafregionalconn
+	region := meta.(*AWSClient).region
+
+	oldRules := d.Get("activated_rule").(*schema.Set).List()
+	err := deleteWafRegionalRuleGroup(d.Id(), oldRules, conn, region)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteWafRegionalRuleGroup(id string, oldRules []interface{}, conn wafRuleGroupConn, region string) error {
+	wr := newWafRegionalRetryer(conn, region)
+
+	if len(oldRules) > 0 {
+		noRules := []interface{}{}
+		err := updateWafRuleGroupResource(id, oldRules, noRules, conn, wr)
+		if err != nil {
+			return fmt.Errorf("Error updating WAF Regional Rule Group Predicates: %s", err)
+		}
+	}
+
+	_, err := wr.RetryWithToken(func(token *string) (interface{}, error) {
+		req := &waf.DeleteRuleGroupInput{
+			ChangeToken: token,
+			RuleGroupId: aws.String(id),
+		}
+		log.Printf("[INFO] Deleting WAF Regional Rule Group")
+		return conn.DeleteRuleGroup(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting WAF Regional Rule Group: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSWafRegionalRuleGroup_basic(t *testing.T) {
+	var group waf.RuleGroup
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "aws_wafregional_rule_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafRegionalRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafRegionalRuleGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafRegionalRuleGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "metric_name", rName),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAWSWafRegionalRuleGroup_disappears deletes the rule group
+// out-of-band after apply and before the follow-up refresh, locking in that
+// resourceAwsWafRegionalRuleGroupRead treats a WAFNonexistentItemException
+// as the resource no longer existing rather than surfacing it as an error.
+func TestAccAWSWafRegionalRuleGroup_disappears(t *testing.T) {
+	var group waf.RuleGroup
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "aws_wafregional_rule_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafRegionalRuleGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafRegionalRuleGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafRegionalRuleGroupExists(resourceName, &group),
+					testAccCheckResourceDisappears(testAccProvider, resourceAwsWafRegionalRuleGroup(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSWafRegionalRuleGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).wafregionalconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_wafregional_rule_group" {
+			continue
+		}
+
+		resp, err := conn.GetRuleGroup(&waf.GetRuleGroupInput{
+			RuleGroupId: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			if resp.RuleGroup != nil && aws.StringValue(resp.RuleGroup.RuleGroupId) == rs.Primary.ID {
+				return fmt.Errorf("WAF Regional Rule Group %s still exists", rs.Primary.ID)
+			}
+		}
+
+		if !isAWSErr(err, "WAFNonexistentItemException", "") {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSWafRegionalRuleGroupExists(n string, group *waf.RuleGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No WAF Regional Rule Group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).wafregionalconn
+		resp, err := conn.GetRuleGroup(&waf.GetRuleGroupInput{
+			RuleGroupId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if aws.StringValue(resp.RuleGroup.RuleGroupId) != rs.Primary.ID {
+			return fmt.Errorf("WAF Regional Rule Group not found")
+		}
+
+		*group = *resp.RuleGroup
+		return nil
+	}
+}
+
+func testAccAWSWafRegionalRuleGroupConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_wafregional_rule_group" "test" {
+  name        = %[1]q
+  metric_name = %[1]q
+}
+`, name)
+}
@@ -0,0 +1,250 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// wafRuleGroupConn is satisfied by both the global *waf.WAF client and the
+// regional *wafregional.WAFRegional client. The two are distinct concrete
+// types, but wafregional's request/response shapes are aliases of waf's, so
+// a single interface lets aws_waf_rule_group and aws_wafregional_rule_group
+// (and their data sources) share one implementation instead of forking it.
+type wafRuleGroupConn interface {
+	CreateRuleGroup(*waf.CreateRuleGroupInput) (*waf.CreateRuleGroupOutput, error)
+	GetRuleGroup(*waf.GetRuleGroupInput) (*waf.GetRuleGroupOutput, error)
+	UpdateRuleGroup(*waf.UpdateRuleGroupInput) (*waf.UpdateRuleGroupOutput, error)
+	DeleteRuleGroup(*waf.DeleteRuleGroupInput) (*waf.DeleteRuleGroupOutput, error)
+	ListRuleGroups(*waf.ListRuleGroupsInput) (*waf.ListRuleGroupsOutput, error)
+	ListActivatedRulesInRuleGroup(*waf.ListActivatedRulesInRuleGroupInput) (*waf.ListActivatedRulesInRuleGroupOutput, error)
+	GetChangeToken(*waf.GetChangeTokenInput) (*waf.GetChangeTokenOutput, error)
+}
+
+var _ wafRuleGroupConn = (*waf.WAF)(nil)
+var _ wafRuleGroupConn = (*wafregional.WAFRegional)(nil)
+
+// wafRuleGroupRetryer wraps the change-token dance required by every WAF
+// mutation. newWafRetryer (global, mutex key "global") and
+// newWafRegionalRetryer (below, mutex key per region) both return one.
+type wafRuleGroupRetryer interface {
+	RetryWithToken(func(token *string) (interface{}, error)) (interface{}, error)
+}
+
+type wafRegionalRuleGroupRetryer struct {
+	Connection wafRuleGroupConn
+	Region     string
+}
+
+// newWafRegionalRetryer is the wafregional counterpart to newWafRetryer: it
+// serializes change-token acquisition per region instead of under the
+// hard-coded "global" mutex key used by WAF Classic.
+func newWafRegionalRetryer(conn wafRuleGroupConn, region string) *wafRegionalRuleGroupRetryer {
+	return &wafRegionalRuleGroupRetryer{Connection: conn, Region: region}
+}
+
+func (t *wafRegionalRuleGroupRetryer) RetryWithToken(f func(token *string) (interface{}, error)) (interface{}, error) {
+	mutexKey := fmt.Sprintf("WafRetryer-%s", t.Region)
+	awsMutexKV.Lock(mutexKey)
+	defer awsMutexKV.Unlock(mutexKey)
+
+	var out interface{}
+	var tokenOut *waf.GetChangeTokenOutput
+	var err error
+
+	err = resource.Retry(15*time.Minute, func() *resource.RetryError {
+		tokenOut, err = t.Connection.GetChangeToken(&waf.GetChangeTokenInput{})
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("Failed to acquire change token: %s", err))
+		}
+
+		out, err = f(tokenOut.ChangeToken)
+		if err != nil {
+			if isResourceTimeoutError(err) {
+				return resource.RetryableError(err)
+			}
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "WAFStaleDataException" {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	return out, err
+}
+
+// wafRuleGroupUpdatesChunkSize is the maximum number of ActivatedRule updates
+// sent in a single UpdateRuleGroup call. The WAF API caps the number of
+// updates accepted per change token, so larger diffs must be split into
+// multiple calls, each with its own change token. It is a var rather than a
+// const so tests can lower it to force multi-chunk behavior
+// without needing hundreds of activated_rule blocks.
+var wafRuleGroupUpdatesChunkSize = 100
+
+func updateWafRuleGroupResource(id string, oldRules, newRules []interface{}, conn wafRuleGroupConn, wr wafRuleGroupRetryer) error {
+	for _, chunk := range chunkWafRuleGroupUpdates(diffWafRuleGroupActivatedRules(oldRules, newRules), wafRuleGroupUpdatesChunkSize) {
+		_, err := wr.RetryWithToken(func(token *string) (interface{}, error) {
+			req := &waf.UpdateRuleGroupInput{
+				ChangeToken: token,
+				RuleGroupId: aws.String(id),
+				Updates:     chunk,
+			}
+
+			return conn.UpdateRuleGroup(req)
+		})
+		if err != nil {
+			return fmt.Errorf("Error Updating WAF Rule Group: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// chunkWafRuleGroupUpdates splits updates into fixed-size slices, preserving
+// order so that deletes (emitted first by diffWafRuleGroupActivatedRules)
+// are always sent ahead of inserts.
+func chunkWafRuleGroupUpdates(updates []*waf.RuleGroupUpdate, size int) [][]*waf.RuleGroupUpdate {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	var chunks [][]*waf.RuleGroupUpdate
+	for size < len(updates) {
+		updates, chunks = updates[size:], append(chunks, updates[0:size:size])
+	}
+	chunks = append(chunks, updates)
+
+	return chunks
+}
+
+func diffWafRuleGroupActivatedRules(oldRules, newRules []interface{}) []*waf.RuleGroupUpdate {
+	updates := make([]*waf.RuleGroupUpdate, 0)
+
+	for _, op := range oldRules {
+		rule := op.(map[string]interface{})
+
+		if idx, contains := sliceContainsMap(newRules, rule); contains {
+			newRules = append(newRules[:idx], newRules[idx+1:]...)
+			continue
+		}
+
+		updates = append(updates, &waf.RuleGroupUpdate{
+			Action:        aws.String(waf.ChangeActionDelete),
+			ActivatedRule: expandWafActivatedRule(rule),
+		})
+	}
+
+	for _, np := range newRules {
+		rule := np.(map[string]interface{})
+
+		updates = append(updates, &waf.RuleGroupUpdate{
+			Action:        aws.String(waf.ChangeActionInsert),
+			ActivatedRule: expandWafActivatedRule(rule),
+		})
+	}
+	return updates
+}
+
+func flattenWafActivatedRules(activatedRules []*waf.ActivatedRule) []interface{} {
+	out := make([]interface{}, len(activatedRules), len(activatedRules))
+	for i, ar := range activatedRules {
+		rule := map[string]interface{}{
+			"priority": int(*ar.Priority),
+			"rule_id":  *ar.RuleId,
+			"type":     *ar.Type,
+		}
+		if ar.Action != nil {
+			rule["action"] = []interface{}{
+				map[string]interface{}{
+					"type": *ar.Action.Type,
+				},
+			}
+		}
+		if ar.OverrideAction != nil {
+			rule["override_action"] = []interface{}{
+				map[string]interface{}{
+					"type": *ar.OverrideAction.Type,
+				},
+			}
+		}
+		out[i] = rule
+	}
+	return out
+}
+
+func expandWafActivatedRule(rule map[string]interface{}) *waf.ActivatedRule {
+	r := &waf.ActivatedRule{
+		Priority: aws.Int64(int64(rule["priority"].(int))),
+		RuleId:   aws.String(rule["rule_id"].(string)),
+		Type:     aws.String(rule["type"].(string)),
+	}
+
+	if a, ok := rule["action"].([]interface{}); ok && len(a) > 0 {
+		m := a[0].(map[string]interface{})
+		r.Action = &waf.WafAction{
+			Type: aws.String(m["type"].(string)),
+		}
+	}
+	if a, ok := rule["override_action"].([]interface{}); ok && len(a) > 0 {
+		m := a[0].(map[string]interface{})
+		r.OverrideAction = &waf.WafOverrideAction{
+			Type: aws.String(m["type"].(string)),
+		}
+	}
+	return r
+}
+
+// findWafRuleGroupId looks up a rule group's id by name, paginating via
+// NextMarker, since ListRuleGroups does not support filtering by name.
+func findWafRuleGroupId(conn wafRuleGroupConn, name string) (string, error) {
+	input := &waf.ListRuleGroupsInput{}
+
+	for {
+		resp, err := conn.ListRuleGroups(input)
+		if err != nil {
+			return "", fmt.Errorf("Error reading WAF Rule Groups: %s", err)
+		}
+
+		for _, rg := range resp.RuleGroups {
+			if aws.StringValue(rg.Name) == name {
+				return aws.StringValue(rg.RuleGroupId), nil
+			}
+		}
+
+		if resp.NextMarker == nil {
+			return "", fmt.Errorf("WAF Rule Group not found for name: %s", name)
+		}
+		input.NextMarker = resp.NextMarker
+	}
+}
+
+// listWafActivatedRulesInRuleGroup returns every ActivatedRule in a rule
+// group, paginating via NextMarker until AWS stops returning one.
+func listWafActivatedRulesInRuleGroup(conn wafRuleGroupConn, ruleGroupId string) ([]*waf.ActivatedRule, error) {
+	var activatedRules []*waf.ActivatedRule
+	input := &waf.ListActivatedRulesInRuleGroupInput{
+		RuleGroupId: aws.String(ruleGroupId),
+	}
+
+	for {
+		resp, err := conn.ListActivatedRulesInRuleGroup(input)
+		if err != nil {
+			return nil, err
+		}
+
+		activatedRules = append(activatedRules, resp.ActivatedRules...)
+
+		if resp.NextMarker == nil {
+			break
+		}
+		input.NextMarker = resp.NextMarker
+	}
+
+	return activatedRules, nil
+}
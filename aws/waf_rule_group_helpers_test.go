@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+)
+
+func TestChunkWafRuleGroupUpdates(t *testing.T) {
+	updates := make([]*waf.RuleGroupUpdate, 5)
+	for i := range updates {
+		updates[i] = &waf.RuleGroupUpdate{Action: aws.String(waf.ChangeActionInsert)}
+	}
+
+	chunks := chunkWafRuleGroupUpdates(updates, 2)
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+	if len(chunks[0]) != 2 || len(chunks[1]) != 2 || len(chunks[2]) != 1 {
+		t.Fatalf("unexpected chunk sizes: %d, %d, %d", len(chunks[0]), len(chunks[1]), len(chunks[2]))
+	}
+}
+
+func TestChunkWafRuleGroupUpdates_empty(t *testing.T) {
+	if chunks := chunkWafRuleGroupUpdates(nil, 100); chunks != nil {
+		t.Fatalf("expected nil chunks for no updates, got %v", chunks)
+	}
+}
+
+// testWafRuleGroupConn is a wafRuleGroupConn stub that only implements
+// UpdateRuleGroup, counting how many times it's called. Embedding the
+// interface with a nil value satisfies the rest of the method set without
+// requiring a full fake of every WAF API call.
+type testWafRuleGroupConn struct {
+	wafRuleGroupConn
+	updateCalls int
+}
+
+func (c *testWafRuleGroupConn) UpdateRuleGroup(*waf.UpdateRuleGroupInput) (*waf.UpdateRuleGroupOutput, error) {
+	c.updateCalls++
+	return &waf.UpdateRuleGroupOutput{}, nil
+}
+
+type testWafRuleGroupRetryer struct{}
+
+func (testWafRuleGroupRetryer) RetryWithToken(f func(token *string) (interface{}, error)) (interface{}, error) {
+	return f(aws.String("test-token"))
+}
+
+// TestUpdateWafRuleGroupResourceRespectsChunkSize locks in that
+// wafRuleGroupUpdatesChunkSize is actually read by updateWafRuleGroupResource,
+// by lowering it to force a diff that would otherwise fit in a single
+// UpdateRuleGroup call to span multiple change tokens instead.
+func TestUpdateWafRuleGroupResourceRespectsChunkSize(t *testing.T) {
+	oldSize := wafRuleGroupUpdatesChunkSize
+	wafRuleGroupUpdatesChunkSize = 1
+	defer func() { wafRuleGroupUpdatesChunkSize = oldSize }()
+
+	newRules := []interface{}{
+		map[string]interface{}{
+			"priority": 1,
+			"rule_id":  "rule1",
+			"type":     waf.WafRuleTypeRegular,
+			"action":   []interface{}{map[string]interface{}{"type": waf.WafActionTypeBlock}},
+		},
+		map[string]interface{}{
+			"priority": 2,
+			"rule_id":  "rule2",
+			"type":     waf.WafRuleTypeRegular,
+			"action":   []interface{}{map[string]interface{}{"type": waf.WafActionTypeBlock}},
+		},
+	}
+
+	conn := &testWafRuleGroupConn{}
+	if err := updateWafRuleGroupResource("rule-group-id", nil, newRules, conn, testWafRuleGroupRetryer{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if conn.updateCalls != 2 {
+		t.Fatalf("expected 2 UpdateRuleGroup calls with chunk size 1, got %d", conn.updateCalls)
+	}
+}